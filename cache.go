@@ -0,0 +1,111 @@
+package wstructs
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldMeta is the cached, tag-aware metadata for a single struct field.
+type fieldMeta struct {
+	field reflect.StructField
+	name  string     // tag name, or "" if untagged
+	opts  TagOptions // tag options, parsed once
+	skip  bool       // true if tagged "-" under the cache's tag name
+}
+
+// typeInfo memoizes everything getFields, Field, Map and Set would
+// otherwise recompute via Type.FieldByName / NumField on every call: the
+// ordered fields of a struct type, their parsed tag metadata, and an index
+// from both the Go field name and the tag name to the field's position.
+type typeInfo struct {
+	fields []fieldMeta
+	byName map[string]int
+}
+
+// cacheKey identifies a typeInfo: the tag name is part of the key because
+// the same struct type can be walked under different tag names (a custom
+// Struct.TagName).
+type cacheKey struct {
+	typ     reflect.Type
+	tagName string
+}
+
+// typeCache memoizes typeInfo per (reflect.Type, tag name). It's a
+// sync.Map rather than a mutex-guarded map so that reads - the hot path -
+// are lock-free once a type has been warmed up.
+var typeCache sync.Map // cacheKey -> *typeInfo
+
+// typeInfoFor returns the cached typeInfo for t under tagName, building and
+// storing it on first use.
+func typeInfoFor(t reflect.Type, tagName string) *typeInfo {
+	key := cacheKey{typ: t, tagName: tagName}
+
+	if cached, ok := typeCache.Load(key); ok {
+		return cached.(*typeInfo)
+	}
+
+	info := buildTypeInfo(t, tagName)
+	actual, _ := typeCache.LoadOrStore(key, info)
+	return actual.(*typeInfo)
+}
+
+func buildTypeInfo(t reflect.Type, tagName string) *typeInfo {
+	info := &typeInfo{
+		fields: make([]fieldMeta, t.NumField()),
+		byName: make(map[string]int, t.NumField()),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, opts := parseTag(sf, tagName)
+		skip := tagName != "" && name == "-"
+
+		info.fields[i] = fieldMeta{field: sf, name: name, opts: opts, skip: skip}
+		if skip {
+			continue
+		}
+
+		info.byName[sf.Name] = i
+		if name != "" {
+			info.byName[name] = i
+		}
+	}
+
+	return info
+}
+
+// parseTag splits the value of sf's tagName tag into a name and its options,
+// the same way Field.TagOptions does.
+func parseTag(sf reflect.StructField, tagName string) (name string, opts TagOptions) {
+	if tagName == "" {
+		return "", nil
+	}
+
+	tag := sf.Tag.Get(tagName)
+	if tag == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(tag, ",")
+	if len(parts) > 1 {
+		opts = TagOptions(parts[1:])
+	}
+	return parts[0], opts
+}
+
+// Prewarm populates the internal type-metadata cache for each of types, so
+// that the first real call to Fields, Map, or Set on that type doesn't pay
+// the reflection cost. types are typically zero values (or pointers to
+// them) of the structs a server expects to convert on its hot path. Only the
+// given types are cached; struct types nested inside them are cached on
+// their first use.
+func Prewarm(types ...interface{}) {
+	for _, t := range types {
+		v, err := structVal(t)
+		if err != nil {
+			continue
+		}
+		typeInfoFor(v.Type(), DefaultTagName)
+	}
+}
@@ -0,0 +1,157 @@
+package wstructs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MultiError aggregates the errors encountered while processing a batch of
+// fields, such as in Patch, so callers can surface every failure in one
+// pass instead of bailing out on the first one.
+type MultiError []error
+
+// Error joins the individual error messages with "; ".
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Diff walks old and new field-by-field and returns a map of the fields
+// whose values differ under reflect.DeepEqual, keyed by tag name (or field
+// name if untagged). Fields tagged `structs:"-"` or `updatable:"false"` are
+// skipped. A nested struct field is recursed into: if the field carries the
+// "flatten" tag option its changed sub-fields are emitted under dotted keys
+// like "Address.City", otherwise the nested diff is emitted as a nested
+// map[string]interface{}. old and new must be the same struct type.
+func Diff(old, new interface{}) (map[string]interface{}, error) {
+	oldVal, err := structVal(old)
+	if err != nil {
+		return nil, fmt.Errorf("structs: diff: old: %w", err)
+	}
+
+	newVal, err := structVal(new)
+	if err != nil {
+		return nil, fmt.Errorf("structs: diff: new: %w", err)
+	}
+
+	if oldVal.Type() != newVal.Type() {
+		return nil, fmt.Errorf("structs: diff: old and new have different types: %s != %s", oldVal.Type(), newVal.Type())
+	}
+
+	out := make(map[string]interface{})
+	diffFields(getFields(oldVal, DefaultTagName), getFields(newVal, DefaultTagName), out)
+	return out, nil
+}
+
+// diffFields compares oldFields against newFields pairwise (they come from
+// the same struct type, so they line up by index) and writes the changed
+// ones into out.
+func diffFields(oldFields, newFields []*Field, out map[string]interface{}) {
+	for i, of := range oldFields {
+		nf := newFields[i]
+
+		if !of.IsExported() {
+			continue
+		}
+
+		name, opts := of.TagOptions(of.defaultTag)
+		if name == "-" || of.Tag("updatable") == "false" {
+			continue
+		}
+		if name == "" {
+			name = of.Name()
+		}
+
+		if of.Kind() == reflect.Struct && !opts.Has("omitnested") {
+			nested := make(map[string]interface{})
+			diffFields(getFields(of.value, of.defaultTag), getFields(nf.value, nf.defaultTag), nested)
+			if len(nested) == 0 {
+				continue
+			}
+
+			if opts.Has("flatten") {
+				for k, v := range nested {
+					out[name+"."+k] = v
+				}
+			} else {
+				out[name] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(of.Value(), nf.Value()) {
+			out[name] = nf.Value()
+		}
+	}
+}
+
+// Patch applies patch onto dst, which must be a pointer to a struct. Each
+// entry is matched against dst's fields by tag name (or field name if
+// untagged, with dotted keys such as "Address.City" addressing a nested
+// struct) and assigned via Field.SetConvert, which rejects a numeric
+// conversion rather than truncating or rounding it. Patch returns a
+// MultiError listing every field that failed, or nil if every entry
+// applied cleanly.
+func Patch(dst interface{}, patch map[string]interface{}) error {
+	s := New(dst)
+
+	var merr MultiError
+	for name, val := range patch {
+		field, ok := findPatchField(s, name)
+		if !ok {
+			merr = append(merr, fmt.Errorf("structs: field %q not found", name))
+			continue
+		}
+
+		if err := setPatchValue(field, val); err != nil {
+			merr = append(merr, fmt.Errorf("structs: field %q: %w", name, err))
+		}
+	}
+
+	if len(merr) > 0 {
+		return merr
+	}
+	return nil
+}
+
+// findPatchField resolves name (possibly a dotted path into a nested
+// struct) against s's fields, honoring tag names.
+func findPatchField(s *Struct, name string) (*Field, bool) {
+	parts := strings.SplitN(name, ".", 2)
+	head := parts[0]
+
+	for _, f := range s.Fields() {
+		fname, _ := f.TagOptions(s.TagName)
+		if fname == "" {
+			fname = f.Name()
+		}
+		if fname != head {
+			continue
+		}
+
+		if len(parts) == 1 {
+			return f, true
+		}
+		if f.Kind() != reflect.Struct || !f.value.CanAddr() {
+			return nil, false
+		}
+		return findPatchField(New(f.value.Addr().Interface()), parts[1])
+	}
+
+	return nil, false
+}
+
+// setPatchValue assigns val to field, recursing for nested struct patches
+// and otherwise converting numeric widths and other convertible types via
+// Field.SetConvert.
+func setPatchValue(field *Field, val interface{}) error {
+	if m, ok := val.(map[string]interface{}); ok && field.Kind() == reflect.Struct && field.value.CanAddr() {
+		return Patch(field.value.Addr().Interface(), m)
+	}
+
+	return field.SetConvert(val)
+}
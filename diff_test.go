@@ -0,0 +1,114 @@
+package wstructs
+
+import "testing"
+
+type diffAddress struct {
+	City string
+}
+
+type diffPerson struct {
+	Name    string
+	Age     int
+	Secret  string `structs:"-"`
+	ID      string `updatable:"false"`
+	Address diffAddress
+	Flat    diffAddress `structs:"flat,flatten"`
+}
+
+func TestDiff(t *testing.T) {
+	oldP := diffPerson{Name: "Jane", Age: 30, Secret: "a", ID: "1", Address: diffAddress{City: "NYC"}, Flat: diffAddress{City: "LA"}}
+	newP := oldP
+	newP.Name = "Janet"
+	newP.Secret = "b"
+	newP.ID = "2"
+	newP.Address.City = "Boston"
+	newP.Flat.City = "SF"
+
+	got, err := Diff(oldP, newP)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got["Name"] != "Janet" {
+		t.Fatalf("expected changed Name in diff, got %v", got["Name"])
+	}
+	if _, ok := got["Secret"]; ok {
+		t.Fatalf("Secret is tagged \"-\" and must not appear in diff")
+	}
+	if _, ok := got["ID"]; ok {
+		t.Fatalf("ID is tagged updatable:false and must not appear in diff")
+	}
+
+	nested, ok := got["Address"].(map[string]interface{})
+	if !ok || nested["City"] != "Boston" {
+		t.Fatalf("expected nested Address diff with City=Boston, got %v", got["Address"])
+	}
+
+	if got["flat.City"] != "SF" {
+		t.Fatalf("expected flattened key \"flat.City\"=SF, got %v", got["flat.City"])
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	p := diffPerson{Name: "Jane"}
+
+	got, err := Diff(p, p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected empty diff for identical structs, got %v", got)
+	}
+}
+
+func TestDiffTypeMismatch(t *testing.T) {
+	type other struct{ X int }
+
+	if _, err := Diff(diffPerson{}, other{}); err == nil {
+		t.Fatalf("expected error diffing two different struct types")
+	}
+}
+
+func TestPatch(t *testing.T) {
+	p := &diffPerson{Name: "Jane", Age: 30}
+
+	err := Patch(p, map[string]interface{}{
+		"Name":         "Janet",
+		"Age":          int64(31),
+		"Address.City": "Boston",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Name != "Janet" || p.Age != 31 || p.Address.City != "Boston" {
+		t.Fatalf("patch did not apply cleanly: %+v", p)
+	}
+}
+
+func TestPatchRejectsLossyNumericConversion(t *testing.T) {
+	type target struct {
+		Score int8
+	}
+	dst := &target{}
+
+	err := Patch(dst, map[string]interface{}{"Score": int64(300)})
+	if err == nil {
+		t.Fatalf("expected Patch to reject an out-of-range numeric conversion")
+	}
+	if dst.Score != 0 {
+		t.Fatalf("field should be left untouched after a rejected conversion, got %d", dst.Score)
+	}
+}
+
+func TestPatchUnknownFieldAggregatesError(t *testing.T) {
+	dst := &diffPerson{}
+
+	err := Patch(dst, map[string]interface{}{"DoesNotExist": 1})
+	if err == nil {
+		t.Fatalf("expected error for unknown field")
+	}
+	if _, ok := err.(MultiError); !ok {
+		t.Fatalf("expected a MultiError, got %T", err)
+	}
+}
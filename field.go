@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 var (
@@ -17,6 +18,10 @@ type Field struct {
 	value      reflect.Value
 	field      reflect.StructField
 	defaultTag string
+	// meta is the cached tag name/options for this field under defaultTag,
+	// populated whenever the Field was built from typeCache. It lets
+	// TagOptions(defaultTag) skip re-parsing the tag string.
+	meta *fieldMeta
 }
 
 // Value returns the underlying value of the field. It panics if the field
@@ -31,6 +36,44 @@ func (f *Field) Tag(key string) string {
 	return f.field.Tag.Get(key)
 }
 
+// TagOptions is a set of comma separated options found after the name in a
+// struct tag, such as "omitempty" and "omitnested" in
+// `structs:"my_name,omitempty,omitnested"`.
+type TagOptions []string
+
+// Has returns true if the given option is present in opts.
+func (opts TagOptions) Has(opt string) bool {
+	for _, o := range opts {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// TagOptions returns the name and options portion of the tag associated
+// with key. The tag is expected to be a comma separated list where the
+// first element is the name and the rest are options, e.g.
+// `structs:"my_name,omitempty,omitnested"`. If the tag is empty, both the
+// name and options are empty.
+func (f *Field) TagOptions(key string) (name string, opts TagOptions) {
+	if f.meta != nil && key == f.defaultTag {
+		return f.meta.name, f.meta.opts
+	}
+
+	tag := f.field.Tag.Get(key)
+	if tag == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(tag, ",")
+	if len(parts) > 1 {
+		opts = TagOptions(parts[1:])
+	}
+
+	return parts[0], opts
+}
+
 // IsAnonymous returns true if the given field is an anonymous field (embedded)
 func (f *Field) IsAnonymous() bool {
 	return f.field.Anonymous
@@ -38,7 +81,7 @@ func (f *Field) IsAnonymous() bool {
 
 // IsExported returns true if the given field is exported.
 func (f *Field) IsExported() bool {
-	return f.field.PkgPath == ""
+	return f.field.IsExported()
 }
 
 // CanInterface reports whether Interface can be used without panicking.
@@ -89,6 +132,50 @@ func (f *Field) Set(val interface{}) error {
 	return nil
 }
 
+// SetConvert sets the field to val like Set, but additionally accepts any
+// val whose reflect.Type is ConvertibleTo the field's type - for example
+// assigning an int to an int64 field, a string to a []byte field, or a
+// time.Duration to an int64 field. Use Set instead if you need kind-exact
+// assignment. The conversion must be exact: SetConvert converts val back to
+// its original type and compares it against the original, and rejects the
+// conversion (e.g. int64(300) into an int8 field, or 3.99 into an int
+// field) if the round trip doesn't reproduce val or the field's type can't
+// be converted back to val's type. If val can't be converted it returns an
+// error identifying the field name and both types; an unexported or
+// unsettable field gives the same errors as Set.
+func (f *Field) SetConvert(val interface{}) error {
+	if !f.IsExported() {
+		return errNotExported
+	}
+	if !f.value.CanSet() {
+		return errNotSettable
+	}
+
+	given := reflect.ValueOf(val)
+	target := f.value.Type()
+
+	if given.Type() == target {
+		f.value.Set(given)
+		return nil
+	}
+
+	if !given.Type().ConvertibleTo(target) {
+		return fmt.Errorf("structs: field %q: cannot convert %s to %s", f.Name(), given.Type(), target)
+	}
+
+	if !target.ConvertibleTo(given.Type()) {
+		return fmt.Errorf("structs: field %q: converting %v (%s) to %s is not reversible", f.Name(), val, given.Type(), target)
+	}
+
+	converted := given.Convert(target)
+	if roundTrip := converted.Convert(given.Type()); !reflect.DeepEqual(given.Interface(), roundTrip.Interface()) {
+		return fmt.Errorf("structs: field %q: converting %v (%s) to %s is lossy", f.Name(), val, given.Type(), target)
+	}
+
+	f.value.Set(converted)
+	return nil
+}
+
 // SetZero sets the field to its zero value. It returns an error if the field is not
 // settable (not addressable or not exported).
 func (f *Field) SetZero() error {
@@ -134,16 +221,17 @@ func (f *Field) Field(name string) (*Field, bool) {
 	if err != nil {
 		return nil, false
 	}
-	t := v.Type()
 
-	field, ok := t.FieldByName(name)
-	if !ok {
+	info := typeInfoFor(v.Type(), f.defaultTag)
+	idx, ok := info.byName[name]
+	if !ok || info.fields[idx].skip {
 		return nil, false
 	}
 
 	return &Field{
-		field:      field,
-		value:      v.FieldByName(name),
+		field:      info.fields[idx].field,
+		value:      v.Field(idx),
 		defaultTag: f.defaultTag,
+		meta:       &info.fields[idx],
 	}, true
 }
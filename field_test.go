@@ -0,0 +1,100 @@
+package wstructs
+
+import "testing"
+
+type fieldTestPerson struct {
+	Name   string
+	Age    int
+	Score  int8
+	secret string
+}
+
+func fieldByName(s *Struct, name string) *Field {
+	for _, f := range s.Fields() {
+		if f.Name() == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func TestFieldIsExported(t *testing.T) {
+	s := New(&fieldTestPerson{})
+
+	name := fieldByName(s, "Name")
+	if name == nil || !name.IsExported() {
+		t.Fatalf("Name should be exported")
+	}
+
+	secret := fieldByName(s, "secret")
+	if secret == nil {
+		t.Fatalf("secret field should be found")
+	}
+	if secret.IsExported() {
+		t.Fatalf("secret should not be exported")
+	}
+}
+
+func TestFieldSetWrongKind(t *testing.T) {
+	s := New(&fieldTestPerson{})
+
+	age := fieldByName(s, "Age")
+	if err := age.Set("not an int"); err == nil {
+		t.Fatalf("expected error setting string into int field")
+	}
+}
+
+func TestFieldSetConvertNarrowsInRangeValue(t *testing.T) {
+	s := New(&fieldTestPerson{})
+
+	score := fieldByName(s, "Score")
+	if err := score.SetConvert(int64(42)); err != nil {
+		t.Fatalf("unexpected error converting int64 to int8: %v", err)
+	}
+	if got := score.Value().(int8); got != 42 {
+		t.Fatalf("got %d, want 42", got)
+	}
+}
+
+func TestFieldSetConvertRejectsOverflow(t *testing.T) {
+	s := New(&fieldTestPerson{})
+
+	score := fieldByName(s, "Score")
+	if err := score.SetConvert(int64(300)); err == nil {
+		t.Fatalf("expected error converting out-of-range int64 into int8 field")
+	}
+	if got := score.Value().(int8); got != 0 {
+		t.Fatalf("field should be left untouched after a rejected conversion, got %d", got)
+	}
+}
+
+func TestFieldSetConvertRejectsFractionalLoss(t *testing.T) {
+	s := New(&fieldTestPerson{})
+
+	age := fieldByName(s, "Age")
+	if err := age.SetConvert(3.99); err == nil {
+		t.Fatalf("expected error converting 3.99 into an int field")
+	}
+}
+
+func TestFieldTagOptions(t *testing.T) {
+	type tagged struct {
+		Name string `structs:"name,omitempty,omitnested"`
+		Skip string `structs:"-"`
+		Bare string
+	}
+
+	s := New(&tagged{})
+
+	name := fieldByName(s, "Name")
+	gotName, opts := name.TagOptions("structs")
+	if gotName != "name" || !opts.Has("omitempty") || !opts.Has("omitnested") || opts.Has("flatten") {
+		t.Fatalf("unexpected TagOptions result: name=%q opts=%v", gotName, opts)
+	}
+
+	bare := fieldByName(s, "Bare")
+	gotName, opts = bare.TagOptions("structs")
+	if gotName != "" || opts != nil {
+		t.Fatalf("untagged field should have empty name and nil opts, got name=%q opts=%v", gotName, opts)
+	}
+}
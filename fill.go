@@ -0,0 +1,227 @@
+package wstructs
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sourceTagName is the struct tag key Fill reads to locate a field's value,
+// e.g. `source:"env:MY_VAR"` or `source:"vault:secret/data/foo#field"`.
+const sourceTagName = "source"
+
+// ValueSource resolves a key to its string value. It is the extension
+// point Struct.Fill uses to populate struct fields from external
+// configuration stores (environment variables, files, Vault, ...).
+type ValueSource interface {
+	// Lookup returns the value for key. ok is false if the source has no
+	// value for key.
+	Lookup(key string) (value string, ok bool, err error)
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = map[string]ValueSource{
+		"env":  EnvSource{},
+		"file": FileSource{},
+		"map":  MapSource{},
+	}
+)
+
+// RegisterSource registers s as the ValueSource for scheme, so that a
+// `source:"scheme:key"` tag dispatches to it. It overwrites any source
+// previously registered under the same scheme.
+func RegisterSource(scheme string, s ValueSource) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[scheme] = s
+}
+
+func lookupSource(scheme string) (ValueSource, bool) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	s, ok := sources[scheme]
+	return s, ok
+}
+
+// EnvSource resolves keys via os.LookupEnv. It backs the built-in "env:"
+// scheme.
+type EnvSource struct{}
+
+// Lookup implements ValueSource.
+func (EnvSource) Lookup(key string) (string, bool, error) {
+	v, ok := os.LookupEnv(key)
+	return v, ok, nil
+}
+
+// FileSource resolves a key to the contents of the file named by key, with
+// trailing newlines removed. It backs the built-in "file:" scheme.
+type FileSource struct{}
+
+// Lookup implements ValueSource.
+func (FileSource) Lookup(key string) (string, bool, error) {
+	b, err := os.ReadFile(key)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return strings.TrimRight(string(b), "\n"), true, nil
+}
+
+// MapSource resolves keys from a plain map. It is primarily useful in
+// tests. The built-in "map:" scheme is backed by an empty MapSource until
+// another one is registered for it with RegisterSource.
+type MapSource map[string]string
+
+// Lookup implements ValueSource.
+func (m MapSource) Lookup(key string) (string, bool, error) {
+	v, ok := m[key]
+	return v, ok, nil
+}
+
+// Fill populates every settable field of s tagged with a `source:"..."` tag.
+// The tag's value is split on the first ":" into a scheme and a key; sources
+// passed explicitly are tried first (matched against the key, in order), and
+// otherwise the scheme is dispatched to whatever ValueSource was registered
+// for it via RegisterSource. The resolved string is converted to
+// the field's kind (bool/int/float/duration via time.ParseDuration), or
+// handed to the field's encoding.TextUnmarshaler if it implements one.
+// Fields without a source tag, or whose source has no value for the key,
+// are left untouched. Fill returns a MultiError listing every field that
+// failed to resolve or convert.
+func (s *Struct) Fill(sources ...ValueSource) error {
+	var merr MultiError
+
+	for _, field := range s.Fields() {
+		if !field.IsExported() || !field.CanSet() {
+			continue
+		}
+
+		tag := field.Tag(sourceTagName)
+		if tag == "" {
+			continue
+		}
+
+		val, err := resolveSource(tag, sources)
+		if err != nil {
+			merr = append(merr, fmt.Errorf("structs: field %q: %w", field.Name(), err))
+			continue
+		}
+		if val == nil {
+			continue
+		}
+
+		if err := setFromString(field, *val); err != nil {
+			merr = append(merr, fmt.Errorf("structs: field %q: %w", field.Name(), err))
+		}
+	}
+
+	if len(merr) > 0 {
+		return merr
+	}
+	return nil
+}
+
+// resolveSource resolves a `source:"scheme:key"` tag value, trying extra in
+// order before falling back to the scheme registered via RegisterSource. A
+// nil result (with a nil error) means the source had no value for the key.
+func resolveSource(tag string, extra []ValueSource) (*string, error) {
+	scheme, key, ok := splitScheme(tag)
+	if !ok {
+		return nil, fmt.Errorf("source tag %q is missing a scheme prefix", tag)
+	}
+
+	for _, src := range extra {
+		v, ok, err := src.Lookup(key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return &v, nil
+		}
+	}
+
+	src, ok := lookupSource(scheme)
+	if !ok {
+		return nil, fmt.Errorf("no source registered for scheme %q", scheme)
+	}
+
+	v, ok, err := src.Lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+// splitScheme splits "scheme:key" into its two parts.
+func splitScheme(tag string) (scheme, key string, ok bool) {
+	i := strings.Index(tag, ":")
+	if i < 0 {
+		return "", "", false
+	}
+	return tag[:i], tag[i+1:], true
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// setFromString converts raw to field's kind and sets it, preferring an
+// encoding.TextUnmarshaler implementation if the field's address implements
+// one.
+func setFromString(field *Field, raw string) error {
+	if field.value.CanAddr() && field.value.Addr().Type().Implements(textUnmarshalerType) {
+		tu := field.value.Addr().Interface().(encoding.TextUnmarshaler)
+		return tu.UnmarshalText([]byte(raw))
+	}
+
+	if field.value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		return field.Set(d)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.Set(reflect.ValueOf(raw).Convert(field.value.Type()).Interface())
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		return field.Set(reflect.ValueOf(b).Convert(field.value.Type()).Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		return field.Set(reflect.ValueOf(n).Convert(field.value.Type()).Interface())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		return field.Set(reflect.ValueOf(n).Convert(field.value.Type()).Interface())
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		return field.Set(reflect.ValueOf(f).Convert(field.value.Type()).Interface())
+	default:
+		return fmt.Errorf("unsupported kind %s for source fill", field.Kind())
+	}
+}
@@ -0,0 +1,96 @@
+package wstructs
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFillFromEnv(t *testing.T) {
+	type target struct {
+		FromEnv string `source:"env:WSTRUCTS_TEST_VAR"`
+	}
+
+	os.Setenv("WSTRUCTS_TEST_VAR", "hello")
+	defer os.Unsetenv("WSTRUCTS_TEST_VAR")
+
+	dst := &target{}
+	if err := New(dst).Fill(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.FromEnv != "hello" {
+		t.Fatalf("got %q, want %q", dst.FromEnv, "hello")
+	}
+}
+
+func TestFillFromExplicitSourceUsesKeyNotFullTag(t *testing.T) {
+	type target struct {
+		FromMap string        `source:"map:foo"`
+		Timeout time.Duration `source:"map:timeout"`
+		Count   int           `source:"map:count"`
+	}
+
+	dst := &target{}
+	src := MapSource{"foo": "bar", "timeout": "5s", "count": "3"}
+
+	if err := New(dst).Fill(src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dst.FromMap != "bar" {
+		t.Fatalf("got FromMap=%q, want %q", dst.FromMap, "bar")
+	}
+	if dst.Timeout != 5*time.Second {
+		t.Fatalf("got Timeout=%v, want 5s", dst.Timeout)
+	}
+	if dst.Count != 3 {
+		t.Fatalf("got Count=%d, want 3", dst.Count)
+	}
+}
+
+func TestFillFromRegisteredSource(t *testing.T) {
+	type target struct {
+		FromMap string `source:"map:foo"`
+	}
+
+	RegisterSource("map", MapSource{"foo": "baz"})
+	defer RegisterSource("map", MapSource{})
+
+	dst := &target{}
+	if err := New(dst).Fill(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.FromMap != "baz" {
+		t.Fatalf("got %q, want %q", dst.FromMap, "baz")
+	}
+}
+
+func TestFillLeavesUntaggedAndMissingFieldsAlone(t *testing.T) {
+	type target struct {
+		FromMap  string `source:"map:foo"`
+		Untagged string
+	}
+
+	dst := &target{Untagged: "unchanged"}
+
+	if err := New(dst).Fill(MapSource{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.Untagged != "unchanged" {
+		t.Fatalf("untagged field must not be touched, got %q", dst.Untagged)
+	}
+	if dst.FromMap != "" {
+		t.Fatalf("field with no matching key must stay zero, got %q", dst.FromMap)
+	}
+}
+
+func TestFillUnknownSchemeErrors(t *testing.T) {
+	type target struct {
+		V string `source:"nosuchscheme:key"`
+	}
+
+	err := New(&target{}).Fill()
+	if err == nil {
+		t.Fatalf("expected error for unregistered scheme")
+	}
+}
@@ -0,0 +1,354 @@
+package wstructs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DefaultTagName is the default tag name for struct fields which provides
+// a more granular to tweak certain structs. Lookup the necessary functions
+// for more info.
+const DefaultTagName = "structs"
+
+var errNotStruct = errors.New("structs: not a struct / pointer to struct")
+
+// Struct encapsulates a struct type to provide several high level functions
+// around the struct.
+type Struct struct {
+	raw     interface{}
+	value   reflect.Value
+	TagName string
+}
+
+// New returns a new *Struct with the struct s. It panics if the s's kind is
+// not struct.
+func New(s interface{}) *Struct {
+	v, err := structVal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Struct{
+		raw:     s,
+		value:   v,
+		TagName: DefaultTagName,
+	}
+}
+
+// structVal unwraps s (dereferencing a pointer if needed) and returns the
+// reflect.Value of the underlying struct. It returns errNotStruct if s is
+// not a struct or a pointer to a struct.
+func structVal(s interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(s)
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, errNotStruct
+	}
+
+	return v, nil
+}
+
+// Fields returns a slice of Fields. A struct tag with the content of "-"
+// ignores the checking of that particular field. Example:
+//
+//   // Field is ignored by this package.
+//   Field int `structs:"-"`
+func (s *Struct) Fields() []*Field {
+	return getFields(s.value, s.TagName)
+}
+
+// Values returns a slice of values of the struct s. For exported fields
+// that are tagged with "-" or are not exported the value is skipped.
+func (s *Struct) Values() []interface{} {
+	fields := s.Fields()
+
+	values := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		if !field.IsExported() {
+			continue
+		}
+
+		values = append(values, field.Value())
+	}
+
+	return values
+}
+
+// Names returns a slice of field names of the struct s.
+func (s *Struct) Names() []string {
+	fields := s.Fields()
+
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name())
+	}
+
+	return names
+}
+
+// Map converts the given struct s to a map[string]interface{}. The field
+// name is the key of the map, unless the field has a tag that overrides the
+// key. Nested structs are converted into
+// map[string]interface{} as well, with a cycle-detection guard so that
+// self-referential graphs don't blow the stack.
+func (s *Struct) Map() map[string]interface{} {
+	out := make(map[string]interface{})
+	s.FillMap(out)
+	return out
+}
+
+// FillMap is the same as Map, but instead of returning a new map it fills
+// the given out map. This is useful when the caller wants to reuse an
+// already allocated map.
+func (s *Struct) FillMap(out map[string]interface{}) {
+	if out == nil {
+		return
+	}
+
+	fillMap(s.Fields(), out, make(map[visitKey]bool))
+}
+
+// Set sets the named field (by Go field name or tag name) of the struct s
+// to val. It returns an error if the field is not found, is tagged "-", or
+// if the value's kind differs from the field's kind (see Field.Set).
+func (s *Struct) Set(name string, val interface{}) error {
+	info := typeInfoFor(s.value.Type(), s.TagName)
+
+	idx, ok := info.byName[name]
+	if !ok {
+		return fmt.Errorf("structs: field %q not found", name)
+	}
+
+	if info.fields[idx].skip {
+		return fmt.Errorf("structs: field %q is not settable", name)
+	}
+
+	field := &Field{field: info.fields[idx].field, value: s.value.Field(idx), defaultTag: s.TagName, meta: &info.fields[idx]}
+	return field.Set(val)
+}
+
+// IsZero returns true if all fields of the struct s are zero values. A
+// struct tagged with "-" is ignored.
+func (s *Struct) IsZero() bool {
+	for _, field := range s.Fields() {
+		if !field.IsExported() {
+			continue
+		}
+
+		if !field.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasZero returns true if at least one field of the struct s is a zero
+// value. A struct tagged with "-" is ignored.
+func (s *Struct) HasZero() bool {
+	for _, field := range s.Fields() {
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmptyValue reports whether v is the zero value for its kind.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Struct:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+	return false
+}
+
+// getFields returns the Fields of v using tagName as the struct tag key.
+// Fields tagged with "-" are skipped entirely. The struct type's field
+// metadata is served from typeCache, so repeated calls for the same
+// (type, tagName) pair don't re-walk the type with reflection.
+func getFields(v reflect.Value, tagName string) []*Field {
+	info := typeInfoFor(v.Type(), tagName)
+
+	fields := make([]*Field, 0, len(info.fields))
+	for i := range info.fields {
+		if info.fields[i].skip {
+			continue
+		}
+
+		fields = append(fields, &Field{
+			field:      info.fields[i].field,
+			value:      v.Field(i),
+			defaultTag: tagName,
+			meta:       &info.fields[i],
+		})
+	}
+
+	return fields
+}
+
+// visitKey identifies a struct value in fillMap's cycle guard. The type is
+// part of the key because a struct's first field shares the struct's address.
+type visitKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// fillMap walks fields and writes their values into out, recursing into
+// nested structs. seen holds the struct values on the current recursion path
+// (keyed by address and type) so self-referential graphs don't cause
+// infinite recursion; a struct met again on that path is emitted as its raw
+// value. Tag options are honored: "-" skips a field, "omitempty" drops zero values,
+// "omitnested" keeps a struct/slice/map field opaque, "flatten" promotes a
+// nested struct's fields into out directly (a key collision means the later
+// field wins), and "string" coerces primitives to their fmt.Sprint form.
+func fillMap(fields []*Field, out map[string]interface{}, seen map[visitKey]bool) {
+	for _, field := range fields {
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := field.TagOptions(field.defaultTag)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+
+		if opts.Has("omitempty") && field.IsZero() {
+			continue
+		}
+
+		val := mapValue(field.value, field.defaultTag, opts, seen)
+
+		if opts.Has("flatten") {
+			if nested, ok := val.(map[string]interface{}); ok {
+				for k, v := range nested {
+					out[k] = v
+				}
+				continue
+			}
+		}
+
+		out[name] = val
+	}
+}
+
+// mapValue converts v into a plain value suitable for a Map(), recursing
+// into nested structs (and structs held by pointers, slices and arrays).
+// opts carries the tag options of the field v came from.
+func mapValue(v reflect.Value, tagName string, opts TagOptions, seen map[visitKey]bool) interface{} {
+	if opts.Has("string") {
+		if s, ok := stringify(v); ok {
+			return s
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return mapValue(v.Elem(), tagName, opts, seen)
+	case reflect.Struct:
+		if opts.Has("omitnested") {
+			return v.Interface()
+		}
+
+		if v.CanAddr() {
+			key := visitKey{addr: v.Addr().Pointer(), typ: v.Type()}
+			if seen[key] {
+				return v.Interface()
+			}
+			seen[key] = true
+			defer delete(seen, key)
+		}
+
+		nested := make(map[string]interface{})
+		fillMap(getFields(v, tagName), nested, seen)
+		return nested
+	case reflect.Slice, reflect.Array:
+		elemKind := v.Type().Elem().Kind()
+		if opts.Has("omitnested") || (elemKind != reflect.Struct && elemKind != reflect.Ptr) {
+			return v.Interface()
+		}
+
+		out := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out[i] = mapValue(v.Index(i), tagName, nil, seen)
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+// stringify returns the fmt.Sprint form of v if v holds a primitive kind,
+// and false if v is a composite kind that can't be meaningfully stringified.
+func stringify(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
+		return "", false
+	default:
+		return fmt.Sprint(v.Interface()), true
+	}
+}
+
+// Map converts the given struct s to a map[string]interface{} using the
+// default tag name. See Struct.Map for details.
+func Map(s interface{}) map[string]interface{} {
+	return New(s).Map()
+}
+
+// FillMap is the package level version of Struct.FillMap.
+func FillMap(s interface{}, out map[string]interface{}) {
+	New(s).FillMap(out)
+}
+
+// Values is the package level version of Struct.Values.
+func Values(s interface{}) []interface{} {
+	return New(s).Values()
+}
+
+// Names is the package level version of Struct.Names.
+func Names(s interface{}) []string {
+	return New(s).Names()
+}
+
+// Fields is the package level version of Struct.Fields.
+func Fields(s interface{}) []*Field {
+	return New(s).Fields()
+}
+
+// IsZero is the package level version of Struct.IsZero.
+func IsZero(s interface{}) bool {
+	return New(s).IsZero()
+}
+
+// HasZero is the package level version of Struct.HasZero.
+func HasZero(s interface{}) bool {
+	return New(s).HasZero()
+}
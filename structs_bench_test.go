@@ -0,0 +1,79 @@
+package wstructs
+
+import (
+	"reflect"
+	"testing"
+)
+
+type benchAddress struct {
+	Street string
+	City   string
+	Zip    string
+}
+
+type benchPerson struct {
+	Name    string
+	Age     int
+	Emails  []string
+	Address benchAddress
+	Extra   map[string]string `structs:"-"`
+}
+
+func newBenchPerson() *benchPerson {
+	return &benchPerson{
+		Name:   "Jane Doe",
+		Age:    32,
+		Emails: []string{"jane@example.com", "jdoe@example.com"},
+		Address: benchAddress{
+			Street: "1 Infinite Loop",
+			City:   "Cupertino",
+			Zip:    "95014",
+		},
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	p := newBenchPerson()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = New(p).Map()
+	}
+}
+
+func BenchmarkFields(b *testing.B) {
+	p := newBenchPerson()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = New(p).Fields()
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	p := newBenchPerson()
+	s := New(p)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Set("Age", i)
+	}
+}
+
+// BenchmarkMapColdCache evicts the type cache before every call, forcing
+// Map to rebuild the field metadata via reflection each time - i.e. it
+// measures the cost the cache exists to avoid. Compare against BenchmarkMap
+// above, which (like any real hot path) only pays that cost once and then
+// hits a warm cache for the remaining b.N-1 iterations.
+func BenchmarkMapColdCache(b *testing.B) {
+	p := newBenchPerson()
+	personKey := cacheKey{typ: reflect.TypeOf(*p), tagName: DefaultTagName}
+	addressKey := cacheKey{typ: reflect.TypeOf(p.Address), tagName: DefaultTagName}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		typeCache.Delete(personKey)
+		typeCache.Delete(addressKey)
+		_ = New(p).Map()
+	}
+}
@@ -0,0 +1,206 @@
+package wstructs
+
+import (
+	"reflect"
+	"testing"
+)
+
+type structsTestAddress struct {
+	City string
+}
+
+type structsTestPerson struct {
+	Name    string
+	Age     int
+	Address structsTestAddress
+}
+
+func TestNewPanicsOnNonStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected New to panic on a non-struct")
+		}
+	}()
+	New(42)
+}
+
+func TestStructMap(t *testing.T) {
+	p := structsTestPerson{Name: "Jane", Age: 30, Address: structsTestAddress{City: "NYC"}}
+
+	m := New(&p).Map()
+
+	if m["Name"] != "Jane" || m["Age"] != 30 {
+		t.Fatalf("unexpected top-level map: %v", m)
+	}
+
+	nested, ok := m["Address"].(map[string]interface{})
+	if !ok || nested["City"] != "NYC" {
+		t.Fatalf("expected nested Address map with City=NYC, got %v", m["Address"])
+	}
+}
+
+func TestStructValuesAndNames(t *testing.T) {
+	p := structsTestPerson{Name: "Jane", Age: 30}
+	s := New(&p)
+
+	names := s.Names()
+	if !reflect.DeepEqual(names, []string{"Name", "Age", "Address"}) {
+		t.Fatalf("unexpected Names(): %v", names)
+	}
+
+	values := s.Values()
+	if len(values) != 3 || values[0] != "Jane" || values[1] != 30 {
+		t.Fatalf("unexpected Values(): %v", values)
+	}
+}
+
+func TestStructFillMap(t *testing.T) {
+	p := structsTestPerson{Name: "Jane"}
+
+	out := map[string]interface{}{"Existing": "kept"}
+	New(&p).FillMap(out)
+
+	if out["Existing"] != "kept" {
+		t.Fatalf("FillMap must not clobber pre-existing keys in out")
+	}
+	if out["Name"] != "Jane" {
+		t.Fatalf("expected Name=Jane in filled map, got %v", out["Name"])
+	}
+}
+
+func TestStructIsZeroAndHasZero(t *testing.T) {
+	zero := structsTestPerson{}
+	if !New(&zero).IsZero() {
+		t.Fatalf("expected zero-valued struct to report IsZero")
+	}
+	if !New(&zero).HasZero() {
+		t.Fatalf("expected zero-valued struct to report HasZero")
+	}
+
+	partial := structsTestPerson{Name: "Jane"}
+	if New(&partial).IsZero() {
+		t.Fatalf("struct with a non-zero field must not report IsZero")
+	}
+	if !New(&partial).HasZero() {
+		t.Fatalf("struct with a remaining zero field must report HasZero")
+	}
+
+	full := structsTestPerson{Name: "Jane", Age: 1, Address: structsTestAddress{City: "NYC"}}
+	if New(&full).HasZero() {
+		t.Fatalf("fully populated struct must not report HasZero")
+	}
+}
+
+func TestStructSet(t *testing.T) {
+	p := &structsTestPerson{}
+	s := New(p)
+
+	if err := s.Set("Age", 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Age != 42 {
+		t.Fatalf("got Age=%d, want 42", p.Age)
+	}
+
+	if err := s.Set("NoSuchField", 1); err == nil {
+		t.Fatalf("expected error setting an unknown field")
+	}
+}
+
+type tagOptionsTestStruct struct {
+	Name     string             `structs:"name"`
+	Age      int                `structs:",omitempty"`
+	Score    float64            `structs:",string"`
+	Nested   structsTestAddress `structs:",omitnested"`
+	Embedded structsTestAddress `structs:"addr,flatten"`
+	Hidden   string             `structs:"-"`
+}
+
+func TestMapHonorsTagName(t *testing.T) {
+	m := New(&tagOptionsTestStruct{Name: "Jane"}).Map()
+
+	if m["name"] != "Jane" {
+		t.Fatalf("expected tag-renamed key \"name\", got %v", m)
+	}
+}
+
+func TestMapHonorsOmitempty(t *testing.T) {
+	m := New(&tagOptionsTestStruct{Name: "Jane", Age: 0}).Map()
+	if _, ok := m["Age"]; ok {
+		t.Fatalf("zero-valued Age must be omitted from the map, got %v", m["Age"])
+	}
+
+	m = New(&tagOptionsTestStruct{Name: "Jane", Age: 5}).Map()
+	if m["Age"] != 5 {
+		t.Fatalf("non-zero Age must appear in the map, got %v", m["Age"])
+	}
+}
+
+func TestMapHonorsOmitnested(t *testing.T) {
+	s := tagOptionsTestStruct{Name: "Jane", Nested: structsTestAddress{City: "NYC"}}
+	m := New(&s).Map()
+
+	if _, ok := m["Nested"].(map[string]interface{}); ok {
+		t.Fatalf("omitnested field must stay opaque, not be recursed into a map")
+	}
+	if addr, ok := m["Nested"].(structsTestAddress); !ok || addr.City != "NYC" {
+		t.Fatalf("expected the raw struct value for an omitnested field, got %#v", m["Nested"])
+	}
+}
+
+func TestMapHonorsFlatten(t *testing.T) {
+	s := tagOptionsTestStruct{Name: "Jane", Embedded: structsTestAddress{City: "Boston"}}
+	m := New(&s).Map()
+
+	if _, ok := m["addr"]; ok {
+		t.Fatalf("flattened field must not appear under its own key")
+	}
+	if m["City"] != "Boston" {
+		t.Fatalf("expected flattened sub-field \"City\"=Boston, got %v", m["City"])
+	}
+}
+
+func TestMapHonorsStringOption(t *testing.T) {
+	s := tagOptionsTestStruct{Name: "Jane", Score: 9.5}
+	m := New(&s).Map()
+
+	if m["Score"] != "9.5" {
+		t.Fatalf("expected string-coerced Score \"9.5\", got %v (%T)", m["Score"], m["Score"])
+	}
+}
+
+func TestMapHonorsSkipTag(t *testing.T) {
+	s := tagOptionsTestStruct{Name: "Jane", Hidden: "secret"}
+	m := New(&s).Map()
+
+	if _, ok := m["Hidden"]; ok {
+		t.Fatalf("field tagged \"-\" must not appear in the map")
+	}
+}
+
+type cyclicNode struct {
+	Name string
+	Next *cyclicNode
+}
+
+// TestMapCycleDetection has no guard of its own against a regression:
+// without cycle detection Map would recurse without end on the
+// self-referential a <-> b graph and crash the test binary with a stack
+// overflow instead of returning.
+func TestMapCycleDetection(t *testing.T) {
+	a := &cyclicNode{Name: "a"}
+	b := &cyclicNode{Name: "b"}
+	a.Next = b
+	b.Next = a
+
+	m := New(a).Map()
+
+	if m["Name"] != "a" {
+		t.Fatalf("unexpected map: %v", m)
+	}
+
+	next, ok := m["Next"].(map[string]interface{})
+	if !ok || next["Name"] != "b" {
+		t.Fatalf("expected nested Next map for b, got %v", m["Next"])
+	}
+}